@@ -0,0 +1,94 @@
+// Copyright 2013 Kevin Gillette. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package bigfrac bridges frac.F to math/big.Rat, for callers that need to
+// promote out of the fast fixed-width frac package when they hit or
+// anticipate overflow, do arbitrary-precision arithmetic, and round back.
+// The base frac package stays free of the math/big import; only this
+// subpackage pays for it.
+package bigfrac
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/extemporalgenome/frac"
+)
+
+// ToRat returns the exact *big.Rat equivalent to x, and false if x.D == 0.
+// Unlike frac.F, math/big.Rat has no representation for the infinities and
+// NaN that a zero denominator stands in for elsewhere in the package (see
+// frac.FromFloat64), so there is no sensible *big.Rat to return.
+func ToRat(x frac.F) (*big.Rat, bool) {
+	if x.D == 0 {
+		return nil, false
+	}
+	return big.NewRat(int64(x.N), int64(x.D)), true
+}
+
+// FromRat returns the frac.F equivalent to r, and false if its numerator or
+// denominator does not fit in an int.
+func FromRat(r *big.Rat) (frac.F, bool) {
+	n, d := r.Num(), r.Denom()
+	if !n.IsInt64() || !d.IsInt64() {
+		return frac.F{}, false
+	}
+	ni, di := n.Int64(), d.Int64()
+	if ni < math.MinInt || ni > math.MaxInt || di < math.MinInt || di > math.MaxInt {
+		return frac.F{}, false
+	}
+	return frac.F{N: int(ni), D: int(di)}, true
+}
+
+// FromRatApprox returns the closest frac.F to r whose denominator does not
+// exceed maxDenom, computed from the continued-fraction convergents of the
+// exact r, so the projection back into F is rounded only once. See
+// frac.FromFloat64 for the equivalent float64-based algorithm.
+func FromRatApprox(r *big.Rat, maxDenom int) frac.F {
+	if maxDenom < 1 {
+		maxDenom = 1
+	}
+	neg := r.Sign() < 0
+	rr := new(big.Rat).Abs(r)
+	num, den := rr.Num(), rr.Denom()
+	md := big.NewInt(int64(maxDenom))
+
+	hPrev2, hPrev1 := big.NewInt(0), big.NewInt(1)
+	kPrev2, kPrev1 := big.NewInt(1), big.NewInt(0)
+	a, rem := new(big.Int), new(big.Int)
+	for den.Sign() != 0 {
+		a.QuoRem(num, den, rem)
+		h := new(big.Int).Add(new(big.Int).Mul(a, hPrev1), hPrev2)
+		k := new(big.Int).Add(new(big.Int).Mul(a, kPrev1), kPrev2)
+		if k.Cmp(md) > 0 {
+			best := hPrev1
+			bestK := kPrev1
+			if kPrev1.Sign() > 0 {
+				a2 := new(big.Int).Quo(new(big.Int).Sub(md, kPrev2), kPrev1)
+				if a2.Cmp(big.NewInt(1)) >= 0 {
+					h2 := new(big.Int).Add(new(big.Int).Mul(a2, hPrev1), hPrev2)
+					k2 := new(big.Int).Add(new(big.Int).Mul(a2, kPrev1), kPrev2)
+					d1 := new(big.Rat).Abs(new(big.Rat).Sub(rr, new(big.Rat).SetFrac(best, bestK)))
+					d2 := new(big.Rat).Abs(new(big.Rat).Sub(rr, new(big.Rat).SetFrac(h2, k2)))
+					if d2.Cmp(d1) < 0 {
+						best, bestK = h2, k2
+					}
+				}
+			}
+			hPrev1, kPrev1 = best, bestK
+			break
+		}
+		hPrev2, kPrev2 = hPrev1, kPrev1
+		hPrev1, kPrev1 = h, k
+		if rem.Sign() == 0 || kPrev1.Cmp(md) == 0 {
+			break
+		}
+		num, den = den, new(big.Int).Set(rem)
+	}
+	n, d := hPrev1.Int64(), kPrev1.Int64()
+	if neg {
+		n = -n
+	}
+	return frac.F{N: int(n), D: int(d)}.Reduce()
+}
@@ -0,0 +1,50 @@
+// Copyright 2013 Kevin Gillette. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bigfrac
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/extemporalgenome/frac"
+)
+
+func TestToRat(t *testing.T) {
+	r, ok := ToRat(frac.F{N: 3, D: 4})
+	if want := big.NewRat(3, 4); !ok || r.Cmp(want) != 0 {
+		t.Errorf("ToRat(3/4) => %v, %v != %v, true", r, ok, want)
+	}
+
+	if _, ok := ToRat(frac.F{N: 1, D: 0}); ok {
+		t.Errorf("ToRat(1/0) => ok")
+	}
+}
+
+func TestFromRat(t *testing.T) {
+	f, ok := FromRat(big.NewRat(6, 8))
+	if !ok || f != (frac.F{N: 3, D: 4}) {
+		t.Errorf("FromRat(6/8) => %+v, %v", f, ok)
+	}
+
+	huge := new(big.Rat).SetInt(new(big.Int).Lsh(big.NewInt(1), 100))
+	if _, ok := FromRat(huge); ok {
+		t.Errorf("FromRat(2^100) => ok")
+	}
+}
+
+func TestFromRatApprox(t *testing.T) {
+	// 16/9 exactly, well within bounds.
+	if f := FromRatApprox(big.NewRat(16, 9), 10); f != (frac.F{N: 16, D: 9}) {
+		t.Errorf("FromRatApprox(16/9, 10) => %+v", f)
+	}
+	// pi to 20 decimal digits, bounded to a denominator of 1000.
+	pi, _ := new(big.Rat).SetString("3.14159265358979323846")
+	if f := FromRatApprox(pi, 1000); f != (frac.F{N: 355, D: 113}) {
+		t.Errorf("FromRatApprox(pi, 1000) => %+v", f)
+	}
+	if f := FromRatApprox(big.NewRat(-7, 2), 10); f != (frac.F{N: -7, D: 2}) {
+		t.Errorf("FromRatApprox(-7/2, 10) => %+v", f)
+	}
+}
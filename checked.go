@@ -0,0 +1,188 @@
+// Copyright 2013 Kevin Gillette. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package frac
+
+import "math/bits"
+
+// intMax and intMin are the bounds of int on the current platform.
+const (
+	intMax = int(^uint(0) >> 1)
+	intMin = -intMax - 1
+)
+
+// MulChecked returns the product of x*y, and false if either the numerator
+// or denominator overflowed.
+func (x F) MulChecked(y F) (F, bool) {
+	n, ok1 := mulChecked(x.N, y.N)
+	d, ok2 := mulChecked(x.D, y.D)
+	return F{n, d}, ok1 && ok2
+}
+
+// DivChecked returns the quotient of x/y, and false if either the numerator
+// or denominator overflowed.
+func (x F) DivChecked(y F) (F, bool) {
+	if y.N < 0 {
+		yn, ok1 := negChecked(y.N)
+		yd, ok2 := negChecked(y.D)
+		if !ok1 || !ok2 {
+			return F{}, false
+		}
+		y.N, y.D = yn, yd
+	}
+	n, ok1 := mulChecked(x.N, y.D)
+	d, ok2 := mulChecked(x.D, y.N)
+	return F{n, d}, ok1 && ok2
+}
+
+// AddChecked returns the sum of x+y, and false if any intermediate value
+// overflowed. If any overflow is detected and reducing x or y changes it,
+// AddChecked retries once with both operands reduced.
+func (x F) AddChecked(y F) (F, bool) {
+	if r, ok := x.addChecked(y); ok {
+		return r, true
+	}
+	rx, ry := x.Reduce(), y.Reduce()
+	if rx == x && ry == y {
+		return F{}, false
+	}
+	return rx.addChecked(ry)
+}
+
+func (x F) addChecked(y F) (F, bool) {
+	if x.D != y.D {
+		xn, ok1 := mulChecked(x.N, y.D)
+		yn, ok2 := mulChecked(y.N, x.D)
+		d, ok3 := mulChecked(x.D, y.D)
+		if !ok1 || !ok2 || !ok3 {
+			return F{}, false
+		}
+		x.N, y.N, x.D = xn, yn, d
+	}
+	n, ok := addChecked(x.N, y.N)
+	return F{n, x.D}, ok
+}
+
+// SubChecked returns the difference of x-y, and false if any intermediate
+// value overflowed. If any overflow is detected and reducing x or y changes
+// it, SubChecked retries once with both operands reduced.
+func (x F) SubChecked(y F) (F, bool) {
+	if r, ok := x.subChecked(y); ok {
+		return r, true
+	}
+	rx, ry := x.Reduce(), y.Reduce()
+	if rx == x && ry == y {
+		return F{}, false
+	}
+	return rx.subChecked(ry)
+}
+
+func (x F) subChecked(y F) (F, bool) {
+	if x.D != y.D {
+		xn, ok1 := mulChecked(x.N, y.D)
+		yn, ok2 := mulChecked(y.N, x.D)
+		d, ok3 := mulChecked(x.D, y.D)
+		if !ok1 || !ok2 || !ok3 {
+			return F{}, false
+		}
+		x.N, y.N, x.D = xn, yn, d
+	}
+	n, ok := subChecked(x.N, y.N)
+	return F{n, x.D}, ok
+}
+
+// NormToChecked returns an equivalent to x scaled to match the minimum
+// shared multiple of y.D, and false if any intermediate value overflowed.
+func (x F) NormToChecked(y F) (F, bool) {
+	if x.D == y.D {
+		return x, true
+	}
+	xr := x.Reduce()
+	m, ok := LCMChecked(xr.D, y.D)
+	if !ok {
+		return F{}, false
+	}
+	n, ok := mulChecked(m/xr.D, xr.N)
+	if !ok {
+		return F{}, false
+	}
+	return F{n, m}, true
+}
+
+// GCDChecked returns the greatest common divisor of x and y, and false if
+// either argument cannot be negated safely (as GCD does internally).
+func GCDChecked(x, y int) (int, bool) {
+	if x == intMin || y == intMin {
+		return 0, false
+	}
+	return GCD(x, y), true
+}
+
+// LCMChecked returns the least common multiple of x and y, and false if the
+// computation overflowed.
+func LCMChecked(x, y int) (int, bool) {
+	if x == y || x == -y {
+		return x, true
+	}
+	p, ok := mulChecked(x/GCD(x, y), y)
+	if !ok || p == intMin {
+		return 0, false
+	}
+	return abs(p), true
+}
+
+// absU returns the unsigned magnitude of x, valid even when x is intMin.
+func absU(x int) uint {
+	if x < 0 {
+		return -uint(x)
+	}
+	return uint(x)
+}
+
+// negChecked returns -x, and false if x cannot be negated without overflow.
+func negChecked(x int) (int, bool) {
+	if x == intMin {
+		return 0, false
+	}
+	return -x, true
+}
+
+// mulChecked returns a*b, and false if the product overflows int. It uses
+// the platform-width hi/lo product from math/bits to detect overflow
+// without relying on a wider intermediate type.
+func mulChecked(a, b int) (int, bool) {
+	neg := (a < 0) != (b < 0)
+	hi, lo := bits.Mul(absU(a), absU(b))
+	if hi != 0 {
+		return 0, false
+	}
+	if neg {
+		if lo > uint(intMax)+1 {
+			return 0, false
+		}
+		return -int(lo), true
+	}
+	if lo > uint(intMax) {
+		return 0, false
+	}
+	return int(lo), true
+}
+
+// addChecked returns a+b, and false if the sum overflows int.
+func addChecked(a, b int) (int, bool) {
+	c := a + b
+	if (b > 0 && c < a) || (b < 0 && c > a) {
+		return 0, false
+	}
+	return c, true
+}
+
+// subChecked returns a-b, and false if the difference overflows int.
+func subChecked(a, b int) (int, bool) {
+	c := a - b
+	if (b < 0 && c < a) || (b > 0 && c > a) {
+		return 0, false
+	}
+	return c, true
+}
@@ -0,0 +1,74 @@
+// Copyright 2013 Kevin Gillette. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package frac
+
+import "testing"
+
+func TestMulChecked(t *testing.T) {
+	if r, ok := (F{1, 2}).MulChecked(F{2, 3}); !ok || r != (F{2, 6}) {
+		t.Errorf("MulChecked => %+v, %v", r, ok)
+	}
+	if _, ok := (F{intMax, 1}).MulChecked(F{2, 1}); ok {
+		t.Errorf("MulChecked of overflowing operands => ok")
+	}
+}
+
+func TestDivChecked(t *testing.T) {
+	if r, ok := (F{1, 2}).DivChecked(F{3, 4}); !ok || r != (F{4, 6}) {
+		t.Errorf("DivChecked => %+v, %v", r, ok)
+	}
+	if _, ok := (F{intMax, 1}).DivChecked(F{1, 2}); ok {
+		t.Errorf("DivChecked of overflowing operands => ok")
+	}
+}
+
+func TestAddChecked(t *testing.T) {
+	if r, ok := (F{1, 2}).AddChecked(F{1, 2}); !ok || r != (F{2, 2}) {
+		t.Errorf("AddChecked => %+v, %v", r, ok)
+	}
+	if _, ok := (F{intMax, 1}).AddChecked(F{1, 1}); ok {
+		t.Errorf("AddChecked of overflowing operands => ok")
+	}
+	// The direct sum overflows, but both operands share a factor of 2, so a
+	// reduce-and-retry should recover.
+	x := F{intMax - 1, 2}
+	y := F{intMax - 1, 2}
+	if r, ok := x.AddChecked(y); !ok || r != (F{intMax - 1, 1}) {
+		t.Errorf("AddChecked with reducible operands => %+v, %v", r, ok)
+	}
+}
+
+func TestSubChecked(t *testing.T) {
+	if r, ok := (F{1, 2}).SubChecked(F{1, 2}); !ok || r != (F{0, 2}) {
+		t.Errorf("SubChecked => %+v, %v", r, ok)
+	}
+	if _, ok := (F{intMin, 1}).SubChecked(F{1, 1}); ok {
+		t.Errorf("SubChecked of overflowing operands => ok")
+	}
+}
+
+func TestNormToChecked(t *testing.T) {
+	if r, ok := (F{1, 2}).NormToChecked(F{1, 3}); !ok || r != (F{3, 6}) {
+		t.Errorf("NormToChecked => %+v, %v", r, ok)
+	}
+	if _, ok := (F{intMax, 1}).NormToChecked(F{1, 2}); ok {
+		t.Errorf("NormToChecked of overflowing operands => ok")
+	}
+}
+
+func TestGCDLCMChecked(t *testing.T) {
+	if g, ok := GCDChecked(12, 18); !ok || g != 6 {
+		t.Errorf("GCDChecked(12, 18) => %d, %v", g, ok)
+	}
+	if _, ok := GCDChecked(intMin, 1); ok {
+		t.Errorf("GCDChecked(intMin, 1) => ok")
+	}
+	if l, ok := LCMChecked(4, 6); !ok || l != 12 {
+		t.Errorf("LCMChecked(4, 6) => %d, %v", l, ok)
+	}
+	if _, ok := LCMChecked(intMax, intMax-1); ok {
+		t.Errorf("LCMChecked of overflowing operands => ok")
+	}
+}
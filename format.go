@@ -0,0 +1,364 @@
+// Copyright 2013 Kevin Gillette. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package frac
+
+import (
+	"fmt"
+	"io"
+	"math/bits"
+	"strconv"
+)
+
+// Format implements fmt.Formatter, so F participates in fmt verbs the way
+// math/big.Rat does. %v and %s print the N/D form; %q prints it quoted;
+// %d prints the integer part, truncated toward zero; %f, %e, and %g print
+// decimal expansions computed by exact long division on N and D, so
+// precision is honored correctly even for denominators that would lose
+// precision as a float64. Width, precision, '+', '-', '0', and '#' are
+// honored as for the equivalent verbs on float64.
+//
+// %g's default precision (6 significant digits, trailing zeros trimmed) is
+// a pragmatic choice: unlike a float64, an exact rational has no inherent
+// "shortest round-tripping" representation to fall back to.
+//
+// x.D == 0 carries no finite value (see FromFloat64), so %d, %f, %e, and
+// %g print "+Inf", "-Inf", or "NaN" per the sign of x.N, matching what
+// Float64 would compute; %v, %s, and %q are unaffected, since String
+// already prints x.N/x.D literally.
+func (x F) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v', 's':
+		writeFmtString(f, x.String(), false)
+	case 'q':
+		writeFmtString(f, strconv.Quote(x.String()), false)
+	case 'd':
+		x.formatD(f)
+	case 'f', 'F':
+		x.formatF(f)
+	case 'e', 'E':
+		x.formatE(f, verb)
+	case 'g', 'G':
+		x.formatG(f, verb)
+	default:
+		fmt.Fprintf(f, "%%!%c(frac.F=%s)", verb, x.String())
+	}
+}
+
+// writeNonFinite writes x's Inf/NaN form if x.D == 0, and reports whether
+// it did so.
+func (x F) writeNonFinite(f fmt.State) bool {
+	if x.D != 0 {
+		return false
+	}
+	s, signLen := "NaN", 0
+	switch {
+	case x.N > 0:
+		s, signLen = "+Inf", 1
+	case x.N < 0:
+		s, signLen = "-Inf", 1
+	}
+	writeFmtString(f, pad(f, s, signLen), true)
+	return true
+}
+
+func (x F) formatD(f fmt.State) {
+	if x.writeNonFinite(f) {
+		return
+	}
+	n := x.N / x.D
+	s := strconv.Itoa(n)
+	signLen := 0
+	if n < 0 {
+		signLen = 1
+	} else if f.Flag('+') {
+		s = "+" + s
+		signLen = 1
+	}
+	writeFmtString(f, pad(f, s, signLen), true)
+}
+
+func (x F) formatF(f fmt.State) {
+	if x.writeNonFinite(f) {
+		return
+	}
+	prec, ok := f.Precision()
+	if !ok {
+		prec = 6
+	}
+	neg, intStr, fracStr := x.decimal(prec)
+	s := signPrefix(neg, f) + intStr
+	if prec > 0 || f.Flag('#') {
+		s += "." + fracStr
+	}
+	signLen := 0
+	if neg || f.Flag('+') {
+		signLen = 1
+	}
+	writeFmtString(f, pad(f, s, signLen), true)
+}
+
+func (x F) formatE(f fmt.State, verb rune) {
+	if x.writeNonFinite(f) {
+		return
+	}
+	prec, ok := f.Precision()
+	if !ok {
+		prec = 6
+	}
+	neg, digits, exp := x.sigDigits(prec + 1)
+	s := signPrefix(neg, f) + string(digits[:1])
+	if prec > 0 || f.Flag('#') {
+		s += "." + string(digits[1:])
+	}
+	e := byte('e')
+	if verb == 'E' {
+		e = 'E'
+	}
+	s += string(e) + expSuffix(exp)
+	signLen := 0
+	if neg || f.Flag('+') {
+		signLen = 1
+	}
+	writeFmtString(f, pad(f, s, signLen), true)
+}
+
+func (x F) formatG(f fmt.State, verb rune) {
+	if x.writeNonFinite(f) {
+		return
+	}
+	prec, ok := f.Precision()
+	if !ok {
+		prec = 6
+	}
+	if prec == 0 {
+		prec = 1
+	}
+	neg, digits, exp := x.sigDigits(prec)
+	if exp < -4 || exp >= prec {
+		s := signPrefix(neg, f) + string(digits[:1])
+		frac := trimZeros(digits[1:], f.Flag('#'))
+		if frac != "" {
+			s += "." + frac
+		}
+		e := byte('e')
+		if verb == 'G' {
+			e = 'E'
+		}
+		s += string(e) + expSuffix(exp)
+		signLen := 0
+		if neg || f.Flag('+') {
+			signLen = 1
+		}
+		writeFmtString(f, pad(f, s, signLen), true)
+		return
+	}
+	// Fixed notation: place the decimal point exp+1 digits in.
+	var intStr, fracStr string
+	if exp >= 0 {
+		intStr, fracStr = string(digits[:exp+1]), string(digits[exp+1:])
+	} else {
+		intStr = "0"
+		fracStr = zeros(-exp-1) + string(digits)
+	}
+	fracStr = trimZeros([]byte(fracStr), f.Flag('#'))
+	s := signPrefix(neg, f) + intStr
+	if fracStr != "" {
+		s += "." + fracStr
+	}
+	signLen := 0
+	if neg || f.Flag('+') {
+		signLen = 1
+	}
+	writeFmtString(f, pad(f, s, signLen), true)
+}
+
+func signPrefix(neg bool, f fmt.State) string {
+	if neg {
+		return "-"
+	}
+	if f.Flag('+') {
+		return "+"
+	}
+	return ""
+}
+
+func expSuffix(exp int) string {
+	sign := byte('+')
+	if exp < 0 {
+		sign = '-'
+		exp = -exp
+	}
+	s := strconv.Itoa(exp)
+	if len(s) < 2 {
+		s = "0" + s
+	}
+	return string(sign) + s
+}
+
+func zeros(n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = '0'
+	}
+	return string(b)
+}
+
+// nextDigit returns the next decimal digit and remainder of the long
+// division of rem*10 by ud, i.e. (rem*10)/ud and (rem*10)%ud. It computes
+// the product as a full 128-bit value via bits.Mul64 rather than rem*10
+// directly, since rem*10 overflows uint64 once rem exceeds about 1.8e18 —
+// well within range for a denominator derived from a valid (64-bit) int.
+func nextDigit(rem, ud uint64) (digit byte, newRem uint64) {
+	hi, lo := bits.Mul64(rem, 10)
+	q, r := bits.Div64(hi, lo, ud)
+	return byte('0' + q), r
+}
+
+func trimZeros(digits []byte, keep bool) string {
+	if keep {
+		return string(digits)
+	}
+	i := len(digits)
+	for i > 0 && digits[i-1] == '0' {
+		i--
+	}
+	return string(digits[:i])
+}
+
+// decimal returns the sign and the exact integer and fractional decimal
+// digits of x to prec fractional digits, rounded half-away-from-zero.
+func (x F) decimal(prec int) (neg bool, intStr, fracStr string) {
+	un, ud, neg := x.unsigned()
+	if un == 0 {
+		neg = false
+	}
+	ip := un / ud
+	rem := un % ud
+	digits := make([]byte, prec)
+	for i := range digits {
+		digits[i], rem = nextDigit(rem, ud)
+	}
+	if rem*2 >= ud {
+		if carry := incDigits(digits); carry {
+			ip++
+		}
+	}
+	return neg, strconv.FormatUint(ip, 10), string(digits)
+}
+
+// sigDigits returns the sign, the first n significant decimal digits of x
+// (rounded half-away-from-zero), and the base-10 exponent of the first
+// digit. x == 0 reports exponent 0 and all-zero digits.
+func (x F) sigDigits(n int) (neg bool, digits []byte, exp int) {
+	un, ud, neg := x.unsigned()
+	if un == 0 {
+		return false, zeroDigits(n), 0
+	}
+	ip := un / ud
+	rem := un % ud
+	next := func() byte {
+		var d byte
+		d, rem = nextDigit(rem, ud)
+		return d
+	}
+	raw := make([]byte, 0, n+1)
+	if ip > 0 {
+		s := strconv.FormatUint(ip, 10)
+		exp = len(s) - 1
+		raw = append(raw, s...)
+		for len(raw) < n+1 {
+			raw = append(raw, next())
+		}
+	} else {
+		exp = -1
+		d := next()
+		for d == '0' {
+			exp--
+			d = next()
+		}
+		raw = append(raw, d)
+		for len(raw) < n+1 {
+			raw = append(raw, next())
+		}
+	}
+	digits = raw[:n]
+	if raw[n] >= '5' {
+		if carry := incDigits(digits); carry {
+			digits = append([]byte{'1'}, digits[:n-1]...)
+			exp++
+		}
+	}
+	return neg, digits, exp
+}
+
+func zeroDigits(n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = '0'
+	}
+	return b
+}
+
+// incDigits adds one to the decimal number represented by digits, in
+// place, and reports whether the increment carried out past the first
+// digit (e.g. "99" -> "00", carry true).
+func incDigits(digits []byte) (carry bool) {
+	for i := len(digits) - 1; i >= 0; i-- {
+		if digits[i] != '9' {
+			digits[i]++
+			return false
+		}
+		digits[i] = '0'
+	}
+	return true
+}
+
+// unsigned returns the magnitude of x.N and x.D as uint64, and whether x is
+// arithmetically negative.
+func (x F) unsigned() (un, ud uint64, neg bool) {
+	n, d := x.N, x.D
+	neg = (n < 0) != (d < 0)
+	if n < 0 {
+		n = -n
+	}
+	if d < 0 {
+		d = -d
+	}
+	return uint64(n), uint64(d), neg
+}
+
+// pad applies fmt.State width and the '-'/'0' flags to s, treating the
+// first signLen bytes of s as a sign that zero-padding must not separate
+// from the digits.
+func pad(f fmt.State, s string, signLen int) string {
+	w, ok := f.Width()
+	if !ok || len(s) >= w {
+		return s
+	}
+	if f.Flag('-') {
+		return s + spaces(w-len(s))
+	}
+	if f.Flag('0') {
+		return s[:signLen] + zeros(w-len(s)) + s[signLen:]
+	}
+	return spaces(w-len(s)) + s
+}
+
+func spaces(n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = ' '
+	}
+	return string(b)
+}
+
+// writeFmtString writes s to f, applying width/'-' padding unless
+// padApplied indicates the caller already applied it (via pad).
+func writeFmtString(f fmt.State, s string, padApplied bool) {
+	if !padApplied {
+		s = pad(f, s, 0)
+	}
+	io.WriteString(f, s)
+}
@@ -0,0 +1,55 @@
+// Copyright 2013 Kevin Gillette. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package frac
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+var formats = []struct {
+	format string
+	f      F
+	out    string
+}{
+	{"%v", F{3, 4}, "3/4"},
+	{"%s", F{3, 4}, "3/4"},
+	{"%q", F{3, 4}, `"3/4"`},
+	{"%d", F{7, 2}, "3"},
+	{"%d", F{-7, 2}, "-3"},
+	{"%f", F{1, 4}, "0.250000"},
+	{"%.2f", F{1, 4}, "0.25"},
+	{"%.0f", F{3, 4}, "1"},
+	{"%.0f", F{1, 4}, "0"},
+	{"%+.2f", F{1, 4}, "+0.25"},
+	{"%8.2f", F{1, 4}, "    0.25"},
+	{"%-8.2f|", F{1, 4}, "0.25    |"},
+	{"%08.2f", F{-1, 4}, "-0000.25"},
+	{"%e", F{1, 4}, "2.500000e-01"},
+	{"%.2e", F{16, 9}, "1.78e+00"},
+	{"%.3g", F{1, 3}, "0.333"},
+	{"%.3g", F{1234, 1}, "1.23e+03"},
+	{"%g", F{5, 1}, "5"},
+	{"%d", F{1, 0}, "+Inf"},
+	{"%d", F{-1, 0}, "-Inf"},
+	{"%d", F{0, 0}, "NaN"},
+	{"%f", F{1, 0}, "+Inf"},
+	{"%e", F{-1, 0}, "-Inf"},
+	{"%g", F{0, 0}, "NaN"},
+	{"%f", F{0, -5}, "0.000000"},
+	{"%g", F{0, -5}, "0"},
+	// Denominator near math.MaxInt: rem*10 in the long-division loop
+	// overflows uint64 unless computed via the 128-bit product.
+	{"%.20f", F{math.MaxInt - 1, math.MaxInt}, "0.99999999999999999989"},
+}
+
+func TestFormat(t *testing.T) {
+	for i, c := range formats {
+		if s := fmt.Sprintf(c.format, c.f); s != c.out {
+			t.Errorf("Sprintf(%q, %+v) => %q != %q [#%d]", c.format, c.f, s, c.out, i)
+		}
+	}
+}
@@ -19,10 +19,15 @@
 //
 // For all arithemtic operations over x and y, of type F, the resulting
 // denominator will never be smaller than max(x.D, y.D). Use Reduce or NormTo
-// to refine the result or prevent overflow as needed.
+// to refine the result or prevent overflow as needed. For callers that would
+// rather detect overflow than avoid it, the Checked-suffixed functions and
+// methods report it via a second bool return instead of silently wrapping.
 package frac
 
-import "strconv"
+import (
+	"math"
+	"strconv"
+)
 
 func Parse(s string) (F, error) {
 	var err error
@@ -41,6 +46,63 @@ func Parse(s string) (F, error) {
 	return x, err
 }
 
+// FromFloat64 returns the closest rational to x whose denominator does not
+// exceed maxDenom, computed via the continued-fraction convergents of x. NaN
+// maps to F{0, 0}, +Inf to F{1, 0}, and -Inf to F{-1, 0}; maxDenom less than
+// 1 is treated as 1. The result is reduced, with a positive D.
+func FromFloat64(x float64, maxDenom int) F {
+	switch {
+	case math.IsNaN(x):
+		return F{0, 0}
+	case math.IsInf(x, 1):
+		return F{1, 0}
+	case math.IsInf(x, -1):
+		return F{-1, 0}
+	}
+	if maxDenom < 1 {
+		maxDenom = 1
+	}
+	neg := x < 0
+	if neg {
+		x = -x
+	}
+	const eps = 1e-12
+	hPrev2, hPrev1 := 0, 1
+	kPrev2, kPrev1 := 1, 0
+	xi := x
+	for {
+		ai := int(math.Floor(xi))
+		h := ai*hPrev1 + hPrev2
+		k := ai*kPrev1 + kPrev2
+		if k > maxDenom {
+			var a2 int
+			if kPrev1 > 0 {
+				a2 = (maxDenom - kPrev2) / kPrev1
+			}
+			best := F{hPrev1, kPrev1}
+			if a2 >= 1 {
+				alt := F{a2*hPrev1 + hPrev2, a2*kPrev1 + kPrev2}
+				if math.Abs(x-alt.Float64()) < math.Abs(x-best.Float64()) {
+					best = alt
+				}
+			}
+			hPrev1, kPrev1 = best.N, best.D
+			break
+		}
+		hPrev2, kPrev2 = hPrev1, kPrev1
+		hPrev1, kPrev1 = h, k
+		frac := xi - float64(ai)
+		if frac < eps || kPrev1 == maxDenom {
+			break
+		}
+		xi = 1 / frac
+	}
+	if neg {
+		hPrev1 = -hPrev1
+	}
+	return F{hPrev1, kPrev1}.Reduce()
+}
+
 // F represents a fraction/ratio/rational. There are no invariants, so all its
 // methods will complete without an error or panic even if either or both fields
 // are negative or if D is zero. To simulate a "divide by zero" panic, see
@@ -146,6 +208,12 @@ func (x F) IsNeg() bool { return x.N^x.D < 0 }
 // Float64 returns the floating-point approximation of x.
 func (x F) Float64() float64 { return float64(x.N) / float64(x.D) }
 
+// Approximate returns the closest rational to x whose denominator does not
+// exceed maxDenom. See FromFloat64 for the underlying algorithm.
+func (x F) Approximate(maxDenom int) F {
+	return FromFloat64(x.Float64(), maxDenom)
+}
+
 // Assert panics if x.D is zero, returning x otherwise.
 func (x F) Assert() F {
 	_ = 1 / x.D
@@ -4,7 +4,10 @@
 
 package frac
 
-import "testing"
+import (
+	"math"
+	"testing"
+)
 
 var factors = []struct {
 	a, b, gcd, lcm int
@@ -86,6 +89,42 @@ func TestStr(t *testing.T) {
 	}
 }
 
+var floats = []struct {
+	x        float64
+	maxDenom int
+	f        F
+}{
+	{1.7777777777777777, 10, F{16, 9}},
+	{1.3333333333333333, 10, F{4, 3}},
+	{0.5, 10, F{1, 2}},
+	{-0.5, 10, F{-1, 2}},
+	{2, 10, F{2, 1}},
+	{0, 10, F{0, 1}},
+}
+
+func TestFromFloat64(t *testing.T) {
+	for i, c := range floats {
+		if f := FromFloat64(c.x, c.maxDenom); f != c.f {
+			t.Errorf("FromFloat64(%v, %d) => %+v != %+v [#%d]", c.x, c.maxDenom, f, c.f, i)
+		}
+	}
+}
+
+func TestFromFloat64Special(t *testing.T) {
+	nan := math.NaN()
+	posInf := math.Inf(1)
+	negInf := math.Inf(-1)
+	if f := FromFloat64(nan, 10); f != (F{0, 0}) {
+		t.Errorf("FromFloat64(NaN, 10) => %+v != {0 0}", f)
+	}
+	if f := FromFloat64(posInf, 10); f != (F{1, 0}) {
+		t.Errorf("FromFloat64(+Inf, 10) => %+v != {1 0}", f)
+	}
+	if f := FromFloat64(negInf, 10); f != (F{-1, 0}) {
+		t.Errorf("FromFloat64(-Inf, 10) => %+v != {-1 0}", f)
+	}
+}
+
 func BenchmarkGCD(b *testing.B) {
 	for i := 0; i <= b.N; i++ {
 		GCD(i, (i+199)*3%211)
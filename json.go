@@ -0,0 +1,27 @@
+// Copyright 2013 Kevin Gillette. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package frac
+
+import "encoding/json"
+
+// MarshalJSON implements json.Marshaler, encoding x as a quoted string in
+// the canonical N/D form.
+func (x F) MarshalJSON() ([]byte, error) {
+	return json.Marshal(x.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, using Parse.
+func (x *F) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	f, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*x = f
+	return nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2013 Kevin Gillette. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package frac
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseAny parses a broader set of notations than Parse: bare integers
+// ("7"), decimals ("1.75"), repeating decimals with a parenthesized
+// repetend ("0.(3)"), scientific notation ("1.5e-2"), mixed numbers
+// ("1 3/4"), and Parse's own "N/D" form. Decimal and scientific inputs are
+// converted exactly, by counting fractional digits and scaling by the
+// appropriate power of ten, rather than by round-tripping through float64.
+// Decimal, scientific, and mixed-number results are reduced, with a positive
+// D; the "N/D" form is returned as Parse returns it, without reducing.
+func ParseAny(s string) (F, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return F{}, fmt.Errorf("frac: ParseAny: empty string")
+	}
+	if i := strings.IndexByte(s, ' '); i >= 0 {
+		return parseMixed(s[:i], strings.TrimSpace(s[i+1:]))
+	}
+	if strings.ContainsRune(s, '/') {
+		f, err := Parse(s)
+		if err != nil {
+			return F{}, fmt.Errorf("frac: ParseAny: invalid N/D form %q: %w", s, err)
+		}
+		return f, nil
+	}
+	return parseDecimal(s)
+}
+
+// parseMixed combines a whole part and an "N/D" fractional part into a
+// single F, as in "1 3/4".
+func parseMixed(whole, frac string) (F, error) {
+	w, err := strconv.Atoi(whole)
+	if err != nil {
+		return F{}, fmt.Errorf("frac: ParseAny: invalid whole part %q: %w", whole, err)
+	}
+	f, err := Parse(frac)
+	if err != nil {
+		return F{}, fmt.Errorf("frac: ParseAny: invalid fraction part %q: %w", frac, err)
+	}
+	if strings.HasPrefix(whole, "-") {
+		return F{w, 1}.Sub(f).Reduce(), nil
+	}
+	return F{w, 1}.Add(f).Reduce(), nil
+}
+
+// parseDecimal parses a bare integer, decimal, repeating decimal, or
+// scientific-notation string into an exact F.
+func parseDecimal(s string) (F, error) {
+	orig := s
+	neg := false
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+
+	mantissa, exp := s, 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		mantissa = s[:i]
+		e, err := strconv.Atoi(s[i+1:])
+		if err != nil {
+			return F{}, fmt.Errorf("frac: ParseAny: invalid exponent in %q: %w", orig, err)
+		}
+		exp = e
+	}
+
+	intPart, fracPart, repPart := mantissa, "", ""
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		intPart, fracPart = mantissa[:i], mantissa[i+1:]
+		if j := strings.IndexByte(fracPart, '('); j >= 0 {
+			if !strings.HasSuffix(fracPart, ")") {
+				return F{}, fmt.Errorf("frac: ParseAny: unterminated repeating group in %q", orig)
+			}
+			fracPart, repPart = fracPart[:j], fracPart[j+1:len(fracPart)-1]
+		}
+	}
+	if intPart == "" {
+		intPart = "0"
+	}
+	for _, r := range intPart + fracPart + repPart {
+		if r < '0' || r > '9' {
+			return F{}, fmt.Errorf("frac: ParseAny: invalid digit in %q", orig)
+		}
+	}
+
+	var f F
+	if repPart == "" {
+		num, err := strconv.Atoi(intPart + fracPart)
+		if err != nil {
+			return F{}, fmt.Errorf("frac: ParseAny: invalid decimal %q: %w", orig, err)
+		}
+		f = F{num, pow10(len(fracPart))}
+	} else {
+		whole, nonRep := 0, 0
+		if intPart != "" {
+			whole, _ = strconv.Atoi(intPart)
+		}
+		if fracPart != "" {
+			nonRep, _ = strconv.Atoi(fracPart)
+		}
+		all, err := strconv.Atoi(fracPart + repPart)
+		if err != nil {
+			return F{}, fmt.Errorf("frac: ParseAny: invalid repeating decimal %q: %w", orig, err)
+		}
+		denom := (pow10(len(repPart)) - 1) * pow10(len(fracPart))
+		f = F{whole, 1}.Add(F{all - nonRep, denom})
+	}
+
+	if exp > 0 {
+		f.N *= pow10(exp)
+	} else if exp < 0 {
+		f.D *= pow10(-exp)
+	}
+	if neg {
+		f.N = -f.N
+	}
+	return f.Reduce(), nil
+}
+
+// pow10 returns 10**n for n >= 0.
+func pow10(n int) int {
+	p := 1
+	for i := 0; i < n; i++ {
+		p *= 10
+	}
+	return p
+}
+
+// MarshalText implements encoding.TextMarshaler, using the canonical N/D
+// form.
+func (x F) MarshalText() ([]byte, error) {
+	return []byte(x.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, using Parse.
+func (x *F) UnmarshalText(text []byte) error {
+	f, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*x = f
+	return nil
+}
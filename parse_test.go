@@ -0,0 +1,68 @@
+// Copyright 2013 Kevin Gillette. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package frac
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+var anyStrs = []struct {
+	i string
+	f F
+}{
+	{"7", F{7, 1}},
+	{"1.75", F{7, 4}},
+	{"0.(3)", F{1, 3}},
+	{"1.5e-2", F{3, 200}},
+	{"1 3/4", F{7, 4}},
+	{"-1 3/4", F{-7, 4}},
+	{"1 2/4", F{3, 2}},
+	{"-0 3/4", F{-3, 4}},
+	{"3/4", F{3, 4}},
+}
+
+func TestParseAny(t *testing.T) {
+	for i, c := range anyStrs {
+		f, err := ParseAny(c.i)
+		if err != nil {
+			t.Errorf("ParseAny(%q) error: %v [#%d]", c.i, err, i)
+			continue
+		}
+		if f != c.f {
+			t.Errorf("ParseAny(%q) => %+v != %+v [#%d]", c.i, f, c.f, i)
+		}
+	}
+}
+
+func TestParseAnyErrors(t *testing.T) {
+	for _, s := range []string{"", "1.2.3", "0.(", "1e", "x/y"} {
+		if _, err := ParseAny(s); err == nil {
+			t.Errorf("ParseAny(%q) => nil error", s)
+		}
+	}
+}
+
+func TestTextMarshal(t *testing.T) {
+	b, err := F{3, 4}.MarshalText()
+	if err != nil || string(b) != "3/4" {
+		t.Errorf("MarshalText() => %q, %v", b, err)
+	}
+	var f F
+	if err := f.UnmarshalText([]byte("3/4")); err != nil || f != (F{3, 4}) {
+		t.Errorf("UnmarshalText(3/4) => %+v, %v", f, err)
+	}
+}
+
+func TestJSONMarshal(t *testing.T) {
+	b, err := json.Marshal(F{3, 4})
+	if err != nil || string(b) != `"3/4"` {
+		t.Errorf("json.Marshal(3/4) => %q, %v", b, err)
+	}
+	var f F
+	if err := json.Unmarshal([]byte(`"3/4"`), &f); err != nil || f != (F{3, 4}) {
+		t.Errorf("json.Unmarshal(\"3/4\") => %+v, %v", f, err)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2013 Kevin Gillette. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package frac
+
+// Slice is a slice of F with aggregation helpers and an implementation of
+// sort.Interface (ordered via Cmp).
+type Slice []F
+
+// Len implements sort.Interface.
+func (s Slice) Len() int { return len(s) }
+
+// Less implements sort.Interface.
+func (s Slice) Less(i, j int) bool { return s[i].Cmp(s[j]) < 0 }
+
+// Swap implements sort.Interface.
+func (s Slice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+// Sum returns the sum of s, or F{0, 1} if s is empty. Unlike repeated Add,
+// which accumulates a common denominator pairwise and can blow it up well
+// past what's needed, Sum computes a single common denominator via
+// repeated LCM over the reduced elements of s first, then accumulates the
+// numerator in one pass.
+func (s Slice) Sum() F {
+	if len(s) == 0 {
+		return F{0, 1}
+	}
+	d := s[0].Reduce().D
+	for _, f := range s[1:] {
+		d = LCM(d, f.Reduce().D)
+	}
+	n := 0
+	for _, f := range s {
+		rf := f.Reduce()
+		n += d / rf.D * rf.N
+	}
+	return F{n, d}
+}
+
+// Prod returns the product of s, or F{1, 1} if s is empty.
+func (s Slice) Prod() F {
+	p := F{1, 1}
+	for _, f := range s {
+		p = p.Mul(f)
+	}
+	return p
+}
+
+// Min returns the least element of s. It panics if s is empty.
+func (s Slice) Min() F {
+	m := s[0]
+	for _, f := range s[1:] {
+		if f.Cmp(m) < 0 {
+			m = f
+		}
+	}
+	return m
+}
+
+// Max returns the greatest element of s. It panics if s is empty.
+func (s Slice) Max() F {
+	m := s[0]
+	for _, f := range s[1:] {
+		if f.Cmp(m) > 0 {
+			m = f
+		}
+	}
+	return m
+}
@@ -0,0 +1,54 @@
+// Copyright 2013 Kevin Gillette. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package frac
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestSliceSum(t *testing.T) {
+	s := Slice{F{1, 2}, F{1, 3}, F{1, 6}}
+	if r := s.Sum().Reduce(); r != (F{1, 1}) {
+		t.Errorf("Sum() => %+v != {1 1}", r)
+	}
+	if r := Slice(nil).Sum(); r != (F{0, 1}) {
+		t.Errorf("Sum() of empty slice => %+v != {0 1}", r)
+	}
+	if r := (Slice{F{1, 2}, F{2, 4}}).Sum().Reduce(); r != (F{1, 1}) {
+		t.Errorf("Sum() with unreduced element => %+v != {1 1}", r)
+	}
+}
+
+func TestSliceProd(t *testing.T) {
+	s := Slice{F{1, 2}, F{2, 3}, F{3, 4}}
+	if r := s.Prod().Reduce(); r != (F{1, 4}) {
+		t.Errorf("Prod() => %+v != {1 4}", r)
+	}
+	if r := Slice(nil).Prod(); r != (F{1, 1}) {
+		t.Errorf("Prod() of empty slice => %+v != {1 1}", r)
+	}
+}
+
+func TestSliceMinMax(t *testing.T) {
+	s := Slice{F{3, 4}, F{1, 2}, F{5, 6}}
+	if m := s.Min(); m != (F{1, 2}) {
+		t.Errorf("Min() => %+v != {1 2}", m)
+	}
+	if m := s.Max(); m != (F{5, 6}) {
+		t.Errorf("Max() => %+v != {5 6}", m)
+	}
+}
+
+func TestSliceSort(t *testing.T) {
+	s := Slice{F{3, 4}, F{1, 2}, F{5, 6}, F{1, 3}}
+	sort.Sort(s)
+	want := Slice{F{1, 3}, F{1, 2}, F{3, 4}, F{5, 6}}
+	for i := range s {
+		if s[i] != want[i] {
+			t.Errorf("sorted[%d] => %+v != %+v", i, s[i], want[i])
+		}
+	}
+}